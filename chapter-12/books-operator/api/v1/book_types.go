@@ -1,6 +1,7 @@
 package v1
 
 import (
+    corev1 "k8s.io/api/core/v1"
     metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -19,16 +20,192 @@ type BookSpec struct {
     // +kubebuilder:validation:Minimum=1900
     // +kubebuilder:validation:Maximum=2100
     Year int `json:"year"`
+
+    // Replicas is the desired number of Pods owned by this Book.
+    // Defaults to 1 when unset.
+    // +optional
+    // +kubebuilder:default=1
+    // +kubebuilder:validation:Minimum=0
+    Replicas *int32 `json:"replicas,omitempty"`
+
+    // MirrorClusters lists remote clusters that should each run a copy of this
+    // Book's pod (PodTemplate or the default busybox), in addition to the
+    // local replicas.
+    // +optional
+    MirrorClusters []ClusterRef `json:"mirrorClusters,omitempty"`
+
+    // WorkloadKind selects the kind of workload the reconciler renders the
+    // Book into. Defaults to Pod.
+    // +optional
+    // +kubebuilder:default=Pod
+    // +kubebuilder:validation:Enum=Pod;Deployment;Job;CronJob
+    WorkloadKind WorkloadKind `json:"workloadKind,omitempty"`
+
+    // PodTemplate overrides the default busybox container when set, letting
+    // callers bring their own image and command for any WorkloadKind.
+    // +optional
+    PodTemplate *corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
+
+    // Schedule is the cron schedule used when WorkloadKind is CronJob.
+    // Ignored for every other kind.
+    // +optional
+    // +kubebuilder:default="@hourly"
+    Schedule string `json:"schedule,omitempty"`
+
+    // DeletionPolicy controls how this Book's Pods are removed, both when a
+    // Pod is labeled packt.com/to-delete=true and when the Book itself is
+    // deleted. Defaults to Graceful when unset.
+    // +optional
+    DeletionPolicy *DeletionPolicySpec `json:"deletionPolicy,omitempty"`
+}
+
+// DeletionPolicySpec controls how a Book's Pods are terminated.
+type DeletionPolicySpec struct {
+    // Mode selects Graceful (respect TerminationGracePeriodSeconds and any
+    // PreStopExec hook) or Immediate (force-delete with no grace period)
+    // removal. Defaults to Graceful.
+    // +optional
+    // +kubebuilder:default=Graceful
+    // +kubebuilder:validation:Enum=Graceful;Immediate
+    Mode DeletionMode `json:"mode,omitempty"`
+
+    // TerminationGracePeriodSeconds overrides the Pod's grace period in
+    // Graceful mode. Ignored in Immediate mode.
+    // +optional
+    TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+    // PreStopExec, when set, runs as a PreStop hook in the workload's first
+    // container before it receives SIGTERM. Ignored in Immediate mode.
+    // +optional
+    PreStopExec []string `json:"preStopExec,omitempty"`
+}
+
+// DeletionMode selects how a Book's Pods are terminated.
+type DeletionMode string
+
+const (
+    DeletionModeGraceful  DeletionMode = "Graceful"
+    DeletionModeImmediate DeletionMode = "Immediate"
+)
+
+// WorkloadKind identifies the kind of workload a Book is rendered as.
+type WorkloadKind string
+
+const (
+    WorkloadKindPod        WorkloadKind = "Pod"
+    WorkloadKindDeployment WorkloadKind = "Deployment"
+    WorkloadKindJob        WorkloadKind = "Job"
+    WorkloadKindCronJob    WorkloadKind = "CronJob"
+)
+
+// ClusterRef identifies a remote cluster to mirror a Book's pod into, via a
+// kubeconfig stored in a Secret in the operator's own namespace.
+type ClusterRef struct {
+    // Name identifies this cluster and is used as the key into
+    // BookStatus.MirrorStatuses.
+    // +kubebuilder:validation:Required
+    Name string `json:"name"`
+
+    // SecretName is the name of the Secret (in the operator's namespace)
+    // holding the remote cluster's kubeconfig under the key "kubeconfig".
+    // +kubebuilder:validation:Required
+    SecretName string `json:"secretName"`
 }
 
 // BookStatus defines the observed state of Book
 type BookStatus struct {
-    // INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-    // Important: Run "make" to regenerate code after modifying this file
+    // AvailableReplicas is the number of Pods currently owned and ready for this Book.
+    // +optional
+    AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
+    // PodNames lists the names of the Pods currently owned by this Book.
+    // +optional
+    PodNames []string `json:"podNames,omitempty"`
+
+    // Selector is the label selector used to match Pods owned by this Book,
+    // in string form, as required by the scale subresource.
+    // +optional
+    Selector string `json:"selector,omitempty"`
+
+    // MirrorStatuses reports the state of the mirrored pod in each of
+    // Spec.MirrorClusters, keyed by cluster name.
+    // +optional
+    MirrorStatuses map[string]MirrorStatus `json:"mirrorStatuses,omitempty"`
+
+    // Conditions report the rendered workload's Ready, Progressing, Degraded,
+    // PodReady, and Reconciled state.
+    // +optional
+    // +patchMergeKey=type
+    // +patchStrategy=merge
+    // +listType=map
+    // +listMapKey=type
+    Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+    // Evictions records the most recent Pod evictions triggered by the
+    // packt.com/to-delete label, oldest first, capped at a short rolling
+    // history.
+    // +optional
+    Evictions []EvictionRecord `json:"evictions,omitempty"`
+
+    // LastWorkloadKind is the WorkloadKind the reconciler rendered this Book
+    // into the last time it ran, so a later Reconcile can tell Spec.WorkloadKind
+    // changed and clean up the previous kind's resources before rendering the
+    // new one.
+    // +optional
+    LastWorkloadKind WorkloadKind `json:"lastWorkloadKind,omitempty"`
+
+    // ObservedGeneration is the Spec generation the status above was
+    // computed from.
+    // +optional
+    ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+    // LastReconcileTime is when the reconciler last finished processing this
+    // Book.
+    // +optional
+    LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+}
+
+// EvictionRecord is one entry in BookStatus.Evictions.
+type EvictionRecord struct {
+    // PodName is the name of the Pod that was evicted.
+    PodName string `json:"podName"`
+
+    // Time is when the eviction was processed.
+    Time metav1.Time `json:"time"`
+
+    // Reason is a short, machine-readable cause for the eviction.
+    Reason string `json:"reason"`
+}
+
+// Condition types reported in BookStatus.Conditions.
+const (
+    ConditionTypeReady       = "Ready"
+    ConditionTypeProgressing = "Progressing"
+    ConditionTypeDegraded    = "Degraded"
+    ConditionTypePodReady    = "PodReady"
+    ConditionTypeReconciled  = "Reconciled"
+)
+
+// MirrorStatus reports the observed state of a Book's mirrored pod in one
+// remote cluster.
+type MirrorStatus struct {
+    // PodName is the name of the mirrored pod in the remote cluster.
+    PodName string `json:"podName,omitempty"`
+
+    // Ready reports whether the mirrored pod is running.
+    Ready bool `json:"ready"`
+
+    // Message carries the last error encountered mirroring into this
+    // cluster, if any.
+    // +optional
+    Message string `json:"message,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.availableReplicas,selectorpath=.status.selector
+//+kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.spec.replicas`
+//+kubebuilder:printcolumn:name="Available",type=integer,JSONPath=`.status.availableReplicas`
 
 // Book is the Schema for the books API
 type Book struct {
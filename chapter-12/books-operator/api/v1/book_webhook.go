@@ -0,0 +1,83 @@
+package v1
+
+import (
+    "context"
+    "fmt"
+    "time"
+    "unicode"
+
+    "k8s.io/apimachinery/pkg/runtime"
+    ctrl "sigs.k8s.io/controller-runtime"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-packt-com-v1-book,mutating=false,failurePolicy=fail,sideEffects=None,groups=packt.com,resources=books,verbs=create;update,versions=v1,name=vbook.kb.io,admissionReviewVersions=v1
+
+// BookValidator enforces cross-field Book rules that kubebuilder validation
+// markers can't express: control characters in the title, years too far in
+// the future, and per-namespace title uniqueness.
+type BookValidator struct {
+    Client client.Client
+}
+
+var _ admission.CustomValidator = &BookValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *BookValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+    book, ok := obj.(*Book)
+    if !ok {
+        return nil, fmt.Errorf("expected a Book but got %T", obj)
+    }
+    return nil, v.validate(ctx, book)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *BookValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+    book, ok := newObj.(*Book)
+    if !ok {
+        return nil, fmt.Errorf("expected a Book but got %T", newObj)
+    }
+    return nil, v.validate(ctx, book)
+}
+
+// ValidateDelete implements admission.CustomValidator. Deletions carry no
+// cross-field rules to enforce.
+func (v *BookValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+    return nil, nil
+}
+
+func (v *BookValidator) validate(ctx context.Context, book *Book) error {
+    for _, r := range book.Spec.Book {
+        if unicode.IsControl(r) {
+            return fmt.Errorf("spec.book must not contain control characters")
+        }
+    }
+
+    if maxYear := time.Now().Year() + 1; book.Spec.Year > maxYear {
+        return fmt.Errorf("spec.year must not be later than %d", maxYear)
+    }
+
+    var siblings BookList
+    if err := v.Client.List(ctx, &siblings, client.InNamespace(book.Namespace)); err != nil {
+        return fmt.Errorf("listing existing Books: %w", err)
+    }
+    for _, sibling := range siblings.Items {
+        if sibling.Name == book.Name {
+            continue // this Book being created or updated
+        }
+        if sibling.Spec.Book == book.Spec.Book {
+            return fmt.Errorf("a Book titled %q already exists in namespace %q", book.Spec.Book, book.Namespace)
+        }
+    }
+    return nil
+}
+
+// SetupWebhookWithManager registers the validating webhook with mgr.
+func (v *BookValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+    v.Client = mgr.GetClient()
+    return ctrl.NewWebhookManagedBy(mgr).
+        For(&Book{}).
+        WithValidator(v).
+        Complete()
+}
@@ -3,21 +3,33 @@ package controllers
 import (
     "context"
     "fmt"
+    "reflect"
 
-    "k8s.io/apimachinery/pkg/api/resource"
+    "k8s.io/apimachinery/pkg/api/meta"
     "k8s.io/apimachinery/pkg/runtime"
     ctrl "sigs.k8s.io/controller-runtime"
+    "sigs.k8s.io/controller-runtime/pkg/builder"
     "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+    "sigs.k8s.io/controller-runtime/pkg/event"
     "sigs.k8s.io/controller-runtime/pkg/log"
+    "sigs.k8s.io/controller-runtime/pkg/predicate"
 
     packtv1 "github.com/PacktPublishing/Kubernetes-Interview-Guide/chapter-12/books-operator/api/v1"
     corev1 "k8s.io/api/core/v1"
     metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// bookNameLabel is set on every Pod owned by a Book and used to select them back.
+const bookNameLabel = "packt.com/book-name"
+
 type BookReconciler struct {
     client.Client
     Scheme *runtime.Scheme
+
+    // Mirrors reconciles Book pods into remote clusters named in
+    // Spec.MirrorClusters. Nil is a valid, no-op default.
+    Mirrors *MirrorManager
 }
 
 //+kubebuilder:rbac:groups=packt.com,resources=books,verbs=get;list;watch;create;update;patch;delete
@@ -35,75 +47,176 @@ func (r *BookReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
     if err != nil {
         return ctrl.Result{}, client.IgnoreNotFound(err)
     }
+    statusBase := book.DeepCopy()
 
     // Log the reconciliation
     log.Info("Reconciling Book", "name", book.Name, "namespace", book.Namespace, "book", book.Spec.Book, "year", book.Spec.Year)
 
-    // Define a new Pod object
-    pod := &corev1.Pod{
-        ObjectMeta: metav1.ObjectMeta{
-            Name:      book.Name + "-pod",
-            Namespace: book.Namespace, // Use the Book CR's namespace
-            OwnerReferences: []metav1.OwnerReference{
-                *metav1.NewControllerRef(book, packtv1.GroupVersion.WithKind("Book")),
-            },
-        },
-        Spec: corev1.PodSpec{
-            Containers: []corev1.Container{
-                {
-                    Name:  "busybox",
-                    Image: "busybox:1.36", // Pin image version for reproducibility
-                    Command: []string{
-                        "sh",
-                        "-c",
-                        fmt.Sprintf("while true; do echo Book: %s, Year: %d; sleep 1; done", book.Spec.Book, book.Spec.Year),
-                    },
-                    Resources: corev1.ResourceRequirements{
-                        Requests: corev1.ResourceList{
-                            corev1.ResourceCPU:    resource.MustParse("100m"),
-                            corev1.ResourceMemory: resource.MustParse("128Mi"),
-                        },
-                        Limits: corev1.ResourceList{
-                            corev1.ResourceCPU:    resource.MustParse("200m"),
-                            corev1.ResourceMemory: resource.MustParse("256Mi"),
-                        },
-                    },
-                },
-            },
-        },
+    // Local Pods need to be drained per DeletionPolicy, and mirrored pods in
+    // remote clusters can't rely on owner references at all, so both are
+    // handled via finalizers before the Book is allowed to be removed.
+    if book.DeletionTimestamp.IsZero() {
+        changed := controllerutil.AddFinalizer(book, bookDrainFinalizer)
+        if r.Mirrors != nil {
+            changed = controllerutil.AddFinalizer(book, bookMirrorFinalizer) || changed
+        }
+        if changed {
+            if err := r.Update(ctx, book); err != nil {
+                return ctrl.Result{}, err
+            }
+        }
+    } else {
+        if controllerutil.ContainsFinalizer(book, bookDrainFinalizer) {
+            if err := drainOwnedPods(ctx, r.Client, book); err != nil {
+                log.Error(err, "Failed to drain owned Pods")
+                return ctrl.Result{}, err
+            }
+            controllerutil.RemoveFinalizer(book, bookDrainFinalizer)
+        }
+        if r.Mirrors != nil && controllerutil.ContainsFinalizer(book, bookMirrorFinalizer) {
+            if err := r.Mirrors.Cleanup(ctx, book); err != nil {
+                log.Error(err, "Failed to clean up mirrored pods")
+                return ctrl.Result{}, err
+            }
+            controllerutil.RemoveFinalizer(book, bookMirrorFinalizer)
+        }
+        return ctrl.Result{}, r.Update(ctx, book)
     }
 
-    // Check if the Pod already exists
-    found := &corev1.Pod{}
-    err = r.Get(ctx, client.ObjectKey{Name: pod.Name, Namespace: pod.Namespace}, found)
-    if err != nil && client.IgnoreNotFound(err) != nil {
-        log.Error(err, "Failed to get Pod")
-        return ctrl.Result{}, err
+    desired := int32(1)
+    if book.Spec.Replicas != nil {
+        desired = *book.Spec.Replicas
     }
 
-    if err == nil {
-        // Pod already exists - don't requeue
-        log.Info("Pod already exists", "pod", pod.Name, "namespace", pod.Namespace)
-        return ctrl.Result{}, nil
+    // If WorkloadKind changed since the last Reconcile, the previous kind's
+    // resources would otherwise keep running alongside the new one forever.
+    kind := effectiveWorkloadKind(book.Spec.WorkloadKind)
+    if book.Status.LastWorkloadKind != "" && book.Status.LastWorkloadKind != kind {
+        if err := cleanupStaleWorkload(ctx, r.Client, book, book.Status.LastWorkloadKind); err != nil {
+            log.Error(err, "Failed to clean up stale workload", "from", book.Status.LastWorkloadKind, "to", kind)
+            return ctrl.Result{}, err
+        }
     }
 
-    // Create the Pod
-    log.Info("Creating Pod", "pod", pod.Name, "namespace", pod.Namespace)
-    err = r.Create(ctx, pod)
+    // Render the Book as its chosen WorkloadKind and converge it to the
+    // desired replica count.
+    ready, err := rendererFor(book.Spec.WorkloadKind).Reconcile(ctx, r.Client, r.Scheme, book, desired)
     if err != nil {
-        log.Error(err, "Failed to create Pod", "pod", pod.Name, "namespace", pod.Namespace)
+        log.Error(err, "Failed to reconcile workload")
+        return ctrl.Result{}, err
+    }
+    book.Status.LastWorkloadKind = kind
+
+    book.Status.AvailableReplicas = ready
+    setBookConditions(book, ready, desired)
+
+    if r.Mirrors != nil {
+        r.Mirrors.Reconcile(ctx, book)
+    }
+
+    book.Status.ObservedGeneration = book.Generation
+    now := metav1.Now()
+    book.Status.LastReconcileTime = &now
+
+    if err := r.Status().Patch(ctx, book, client.MergeFrom(statusBase)); err != nil {
+        log.Error(err, "Failed to update Book status")
         return ctrl.Result{}, err
     }
 
-    log.Info("Pod created successfully", "pod", pod.Name, "namespace", pod.Namespace)
-    // Pod created successfully - don't requeue
     return ctrl.Result{}, nil
 }
 
+// setBookConditions derives Ready/Progressing/Degraded from how many
+// instances of book's workload are ready against the desired count.
+func setBookConditions(book *packtv1.Book, ready, desired int32) {
+    status := metav1.ConditionFalse
+    if ready >= desired {
+        status = metav1.ConditionTrue
+    }
+    meta.SetStatusCondition(&book.Status.Conditions, metav1.Condition{
+        Type:               packtv1.ConditionTypeReady,
+        Status:             status,
+        Reason:             "ReplicasReady",
+        Message:            fmt.Sprintf("%d/%d replicas ready", ready, desired),
+        ObservedGeneration: book.Generation,
+    })
+
+    progressing := metav1.ConditionFalse
+    if ready != desired {
+        progressing = metav1.ConditionTrue
+    }
+    meta.SetStatusCondition(&book.Status.Conditions, metav1.Condition{
+        Type:               packtv1.ConditionTypeProgressing,
+        Status:             progressing,
+        Reason:             "ReplicasConverging",
+        Message:            fmt.Sprintf("%d/%d replicas ready", ready, desired),
+        ObservedGeneration: book.Generation,
+    })
+
+    degraded := metav1.ConditionFalse
+    if desired > 0 && ready == 0 {
+        degraded = metav1.ConditionTrue
+    }
+    meta.SetStatusCondition(&book.Status.Conditions, metav1.Condition{
+        Type:               packtv1.ConditionTypeDegraded,
+        Status:             degraded,
+        Reason:             "NoReplicasReady",
+        Message:            fmt.Sprintf("%d/%d replicas ready", ready, desired),
+        ObservedGeneration: book.Generation,
+    })
+
+    podReady := metav1.ConditionFalse
+    if ready > 0 {
+        podReady = metav1.ConditionTrue
+    }
+    meta.SetStatusCondition(&book.Status.Conditions, metav1.Condition{
+        Type:               packtv1.ConditionTypePodReady,
+        Status:             podReady,
+        Reason:             "AtLeastOneReplicaReady",
+        Message:            fmt.Sprintf("%d/%d replicas ready", ready, desired),
+        ObservedGeneration: book.Generation,
+    })
+
+    meta.SetStatusCondition(&book.Status.Conditions, metav1.Condition{
+        Type:               packtv1.ConditionTypeReconciled,
+        Status:             metav1.ConditionTrue,
+        Reason:             "ReconcileSucceeded",
+        Message:            "Book was successfully reconciled",
+        ObservedGeneration: book.Generation,
+    })
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *BookReconciler) SetupWithManager(mgr ctrl.Manager) error {
-    return ctrl.NewControllerManagedBy(mgr).
+    c, err := ctrl.NewControllerManagedBy(mgr).
         For(&packtv1.Book{}).
-        Owns(&corev1.Pod{}). // Watch pods owned by Book CRs
-        Complete(r)
+        // Owns re-triggers reconciliation on owned Pods so replica convergence
+        // reacts to Pod health. Pod creates and deletes always re-trigger it;
+        // Pod updates do so only when the Pod's Status changed, so
+        // metadata-only updates (such as the packt.com/to-delete label) are
+        // ignored.
+        Owns(&corev1.Pod{}, builder.WithPredicates(predicate.Funcs{
+            UpdateFunc: func(e event.UpdateEvent) bool {
+                oldPod, ok := e.ObjectOld.(*corev1.Pod)
+                if !ok {
+                    return true
+                }
+                newPod, ok := e.ObjectNew.(*corev1.Pod)
+                if !ok {
+                    return true
+                }
+                return !reflect.DeepEqual(oldPod.Status, newPod.Status)
+            },
+        })).
+        Build(r)
+    if err != nil {
+        return err
+    }
+
+    // Let the MirrorManager register a Pod watch against each remote cluster
+    // as it discovers them, since those clusters aren't known at startup.
+    if r.Mirrors != nil {
+        r.Mirrors.InjectController(c)
+    }
+    return nil
 }
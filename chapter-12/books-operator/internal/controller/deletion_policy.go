@@ -0,0 +1,82 @@
+package controllers
+
+import (
+    "context"
+    "fmt"
+
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/labels"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+
+    packtv1 "github.com/PacktPublishing/Kubernetes-Interview-Guide/chapter-12/books-operator/api/v1"
+)
+
+// bookDrainFinalizer ensures a Book's Pods are terminated per its
+// DeletionPolicy before the Book itself is allowed to be garbage collected.
+const bookDrainFinalizer = "packt.com/drain-pods"
+
+// maxEvictionHistory bounds BookStatus.Evictions so it doesn't grow forever
+// on a long-lived, frequently-relabeled Book.
+const maxEvictionHistory = 10
+
+// applyDeletionPolicy bakes policy's grace period and PreStop hook into
+// template, so Pods are born already configured for how they'll later be
+// torn down; TerminationGracePeriodSeconds can't be patched onto a live Pod.
+func applyDeletionPolicy(template *corev1.PodTemplateSpec, policy *packtv1.DeletionPolicySpec) {
+    if policy == nil || policy.Mode == packtv1.DeletionModeImmediate {
+        return
+    }
+
+    if policy.TerminationGracePeriodSeconds != nil {
+        template.Spec.TerminationGracePeriodSeconds = policy.TerminationGracePeriodSeconds
+    }
+
+    if len(policy.PreStopExec) > 0 && len(template.Spec.Containers) > 0 {
+        template.Spec.Containers[0].Lifecycle = &corev1.Lifecycle{
+            PreStop: &corev1.LifecycleHandler{
+                Exec: &corev1.ExecAction{Command: policy.PreStopExec},
+            },
+        }
+    }
+}
+
+// evictPod deletes pod according to policy: Immediate force-deletes with no
+// grace period, Graceful (the default) lets the kubelet honor the Pod's own
+// TerminationGracePeriodSeconds and PreStop hook.
+func evictPod(ctx context.Context, c client.Client, pod *corev1.Pod, policy *packtv1.DeletionPolicySpec) error {
+    var opts []client.DeleteOption
+    if policy != nil && policy.Mode == packtv1.DeletionModeImmediate {
+        opts = append(opts, client.GracePeriodSeconds(0))
+    }
+    return client.IgnoreNotFound(c.Delete(ctx, pod, opts...))
+}
+
+// drainOwnedPods evicts every Pod owned by book, per its DeletionPolicy.
+func drainOwnedPods(ctx context.Context, c client.Client, book *packtv1.Book) error {
+    podList := &corev1.PodList{}
+    selector := labels.SelectorFromSet(labels.Set{bookNameLabel: book.Name})
+    if err := c.List(ctx, podList, client.InNamespace(book.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+        return fmt.Errorf("listing owned Pods to drain: %w", err)
+    }
+
+    for i := range podList.Items {
+        if err := evictPod(ctx, c, &podList.Items[i], book.Spec.DeletionPolicy); err != nil {
+            return fmt.Errorf("draining Pod %s: %w", podList.Items[i].Name, err)
+        }
+    }
+    return nil
+}
+
+// recordEviction appends an EvictionRecord to book's status, trimming the
+// oldest entries once maxEvictionHistory is exceeded.
+func recordEviction(book *packtv1.Book, podName, reason string) {
+    book.Status.Evictions = append(book.Status.Evictions, packtv1.EvictionRecord{
+        PodName: podName,
+        Time:    metav1.Now(),
+        Reason:  reason,
+    })
+    if n := len(book.Status.Evictions); n > maxEvictionHistory {
+        book.Status.Evictions = book.Status.Evictions[n-maxEvictionHistory:]
+    }
+}
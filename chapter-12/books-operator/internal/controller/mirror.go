@@ -0,0 +1,205 @@
+package controllers
+
+import (
+    "context"
+    "fmt"
+    "sync"
+
+    "k8s.io/apimachinery/pkg/runtime"
+    "k8s.io/client-go/tools/clientcmd"
+    ctrl "sigs.k8s.io/controller-runtime"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/cluster"
+    "sigs.k8s.io/controller-runtime/pkg/controller"
+    "sigs.k8s.io/controller-runtime/pkg/handler"
+    "sigs.k8s.io/controller-runtime/pkg/log"
+    "sigs.k8s.io/controller-runtime/pkg/source"
+
+    packtv1 "github.com/PacktPublishing/Kubernetes-Interview-Guide/chapter-12/books-operator/api/v1"
+    corev1 "k8s.io/api/core/v1"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// bookMirrorFinalizer ensures mirrored pods in remote clusters are drained
+// before a Book is removed, since cross-cluster objects can't rely on
+// owner references for garbage collection.
+const bookMirrorFinalizer = "packt.com/mirror-cleanup"
+
+// remoteCluster bundles a started remote cluster.Cluster with the cancel
+// function of the context it runs under.
+type remoteCluster struct {
+    cluster.Cluster
+    cancel context.CancelFunc
+}
+
+// MirrorManager constructs and caches a cluster.Cluster per remote cluster
+// referenced by any Book, registers a watch on each with the manager's
+// controller, and reconciles a mirrored copy of the Book's pod into every one
+// of them.
+type MirrorManager struct {
+    localClient client.Client
+    localNS     string
+    scheme      *runtime.Scheme
+    ctrl        controller.Controller
+
+    mu       sync.Mutex
+    clusters map[string]*remoteCluster // keyed by ClusterRef.Name
+}
+
+// NewMirrorManager returns a MirrorManager that loads remote kubeconfigs from
+// Secrets in the operator's own namespace (localNS).
+func NewMirrorManager(localClient client.Client, scheme *runtime.Scheme, localNS string) *MirrorManager {
+    return &MirrorManager{
+        localClient: localClient,
+        localNS:     localNS,
+        scheme:      scheme,
+        clusters:    make(map[string]*remoteCluster),
+    }
+}
+
+// InjectController wires the MirrorManager to the controller so newly
+// discovered remote clusters can register a Pod watch source that re-enqueues
+// the owning Book.
+func (m *MirrorManager) InjectController(c controller.Controller) {
+    m.ctrl = c
+}
+
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+
+// ensureCluster returns the cached cluster.Cluster for ref, constructing,
+// starting, and registering a watch source for it the first time it is seen.
+func (m *MirrorManager) ensureCluster(ctx context.Context, ref packtv1.ClusterRef) (client.Client, error) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    if rc, ok := m.clusters[ref.Name]; ok {
+        return rc.GetClient(), nil
+    }
+
+    secret := &corev1.Secret{}
+    if err := m.localClient.Get(ctx, client.ObjectKey{Namespace: m.localNS, Name: ref.SecretName}, secret); err != nil {
+        return nil, fmt.Errorf("fetching kubeconfig secret %s/%s: %w", m.localNS, ref.SecretName, err)
+    }
+
+    restCfg, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["kubeconfig"])
+    if err != nil {
+        return nil, fmt.Errorf("parsing kubeconfig for cluster %s: %w", ref.Name, err)
+    }
+
+    c, err := cluster.New(restCfg, func(o *cluster.Options) { o.Scheme = m.scheme })
+    if err != nil {
+        return nil, fmt.Errorf("building cluster client for %s: %w", ref.Name, err)
+    }
+
+    runCtx, cancel := context.WithCancel(ctx)
+    go func() {
+        if err := c.Start(runCtx); err != nil {
+            log.FromContext(ctx).Error(err, "Mirror cluster stopped", "cluster", ref.Name)
+        }
+    }()
+
+    if m.ctrl != nil {
+        // Reconcile the owning Book whenever its mirrored pod changes remotely.
+        if err := m.ctrl.Watch(
+            source.NewKindWithCache(&corev1.Pod{}, c.GetCache()),
+            handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []ctrl.Request {
+                name := obj.GetLabels()[bookNameLabel]
+                if name == "" {
+                    return nil
+                }
+                return []ctrl.Request{{NamespacedName: client.ObjectKey{Namespace: obj.GetNamespace(), Name: name}}}
+            }),
+        ); err != nil {
+            cancel()
+            return nil, fmt.Errorf("registering watch for cluster %s: %w", ref.Name, err)
+        }
+    }
+
+    m.clusters[ref.Name] = &remoteCluster{Cluster: c, cancel: cancel}
+    return c.GetClient(), nil
+}
+
+// Reconcile creates book's mirrored pod in every referenced remote cluster
+// where it is missing, and reports the outcome in
+// book.Status.MirrorStatuses.
+func (m *MirrorManager) Reconcile(ctx context.Context, book *packtv1.Book) {
+    log := log.FromContext(ctx)
+    statuses := make(map[string]packtv1.MirrorStatus, len(book.Spec.MirrorClusters))
+
+    for _, ref := range book.Spec.MirrorClusters {
+        remote, err := m.ensureCluster(ctx, ref)
+        if err != nil {
+            log.Error(err, "Failed to reach mirror cluster", "cluster", ref.Name)
+            statuses[ref.Name] = packtv1.MirrorStatus{Message: err.Error()}
+            continue
+        }
+
+        pod := mirrorPod(book)
+        status, err := reconcileMirrorPod(ctx, remote, pod)
+        if err != nil {
+            log.Error(err, "Failed to reconcile mirrored pod", "cluster", ref.Name, "pod", pod.Name)
+            statuses[ref.Name] = packtv1.MirrorStatus{PodName: pod.Name, Message: err.Error()}
+            continue
+        }
+        statuses[ref.Name] = status
+    }
+
+    book.Status.MirrorStatuses = statuses
+}
+
+// reconcileMirrorPod creates pod in the remote cluster if it doesn't already
+// exist. An existing mirrored pod is left as it is: a later change to the
+// Book's PodTemplate is not applied to it.
+func reconcileMirrorPod(ctx context.Context, remote client.Client, pod *corev1.Pod) (packtv1.MirrorStatus, error) {
+    found := &corev1.Pod{}
+    err := remote.Get(ctx, client.ObjectKey{Name: pod.Name, Namespace: pod.Namespace}, found)
+    switch {
+    case err == nil:
+        return packtv1.MirrorStatus{PodName: found.Name, Ready: found.Status.Phase == corev1.PodRunning}, nil
+    case client.IgnoreNotFound(err) != nil:
+        return packtv1.MirrorStatus{}, err
+    }
+
+    if err := remote.Create(ctx, pod); err != nil && client.IgnoreAlreadyExists(err) != nil {
+        return packtv1.MirrorStatus{}, err
+    }
+    return packtv1.MirrorStatus{PodName: pod.Name, Ready: false}, nil
+}
+
+// Cleanup deletes book's mirrored pod from every referenced remote cluster.
+// It is called while draining the mirror finalizer, so Book deletion does not
+// leak pods that owner references (scoped to a single cluster) can't collect.
+func (m *MirrorManager) Cleanup(ctx context.Context, book *packtv1.Book) error {
+    log := log.FromContext(ctx)
+    pod := mirrorPod(book)
+
+    for _, ref := range book.Spec.MirrorClusters {
+        remote, err := m.ensureCluster(ctx, ref)
+        if err != nil {
+            return fmt.Errorf("reaching mirror cluster %s during cleanup: %w", ref.Name, err)
+        }
+        if err := remote.Delete(ctx, pod.DeepCopy()); err != nil && client.IgnoreNotFound(err) != nil {
+            return fmt.Errorf("deleting mirrored pod in cluster %s: %w", ref.Name, err)
+        }
+        log.Info("Deleted mirrored pod", "cluster", ref.Name, "pod", pod.Name)
+    }
+    return nil
+}
+
+// mirrorPod builds the pod mirrored into every remote cluster for book, from
+// the same PodTemplate (override or default busybox) the local workload
+// renders, so a mirror is actually an identical copy of what book runs
+// locally rather than a hardcoded stand-in.
+// Its name is derived solely from the Book so Cleanup can address it without
+// having to list pods in clusters that may already be unreachable.
+func mirrorPod(book *packtv1.Book) *corev1.Pod {
+    template := bookPodTemplate(book)
+    return &corev1.Pod{
+        ObjectMeta: metav1.ObjectMeta{
+            Name:      book.Name + "-mirror",
+            Namespace: book.Namespace,
+            Labels:    template.Labels,
+        },
+        Spec: template.Spec,
+    }
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+    "context"
+
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/runtime"
+    ctrl "sigs.k8s.io/controller-runtime"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/log"
+    "sigs.k8s.io/controller-runtime/pkg/predicate"
+
+    packtv1 "github.com/PacktPublishing/Kubernetes-Interview-Guide/chapter-12/books-operator/api/v1"
+)
+
+// toDeleteLabel, set to "true" on a Pod owned by a Book, requests graceful
+// removal of that Pod according to the owning Book's DeletionPolicy.
+const toDeleteLabel = "packt.com/to-delete"
+
+// PodEvictionReconciler watches for Pods labeled packt.com/to-delete=true
+// and evicts them per the owning Book's DeletionPolicy, recording the
+// outcome on the Book's status.
+type PodEvictionReconciler struct {
+    client.Client
+    Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=packt.com,resources=books,verbs=get;list;watch
+//+kubebuilder:rbac:groups=packt.com,resources=books/status,verbs=get;update;patch
+
+// Reconcile evicts a labeled Pod and records the eviction on its owning Book.
+func (r *PodEvictionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+    log := log.FromContext(ctx)
+
+    pod := &corev1.Pod{}
+    if err := r.Get(ctx, req.NamespacedName, pod); err != nil {
+        return ctrl.Result{}, client.IgnoreNotFound(err)
+    }
+
+    if pod.Labels[toDeleteLabel] != "true" || !pod.DeletionTimestamp.IsZero() {
+        return ctrl.Result{}, nil
+    }
+
+    bookName := pod.Labels[bookNameLabel]
+    if bookName == "" {
+        return ctrl.Result{}, nil
+    }
+
+    book := &packtv1.Book{}
+    if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: bookName}, book); err != nil {
+        return ctrl.Result{}, client.IgnoreNotFound(err)
+    }
+    statusBase := book.DeepCopy()
+
+    log.Info("Evicting labeled Pod", "pod", pod.Name, "book", book.Name)
+    if err := evictPod(ctx, r.Client, pod, book.Spec.DeletionPolicy); err != nil {
+        log.Error(err, "Failed to evict Pod", "pod", pod.Name)
+        return ctrl.Result{}, err
+    }
+
+    recordEviction(book, pod.Name, "LabeledForDeletion")
+    if err := r.Status().Patch(ctx, book, client.MergeFrom(statusBase)); err != nil {
+        log.Error(err, "Failed to record eviction on Book status")
+        return ctrl.Result{}, err
+    }
+
+    return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PodEvictionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+    return ctrl.NewControllerManagedBy(mgr).
+        For(&corev1.Pod{}).
+        WithEventFilter(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+            return obj.GetLabels()[toDeleteLabel] == "true"
+        })).
+        Complete(r)
+}
@@ -0,0 +1,315 @@
+package controllers
+
+import (
+    "context"
+    "fmt"
+
+    appsv1 "k8s.io/api/apps/v1"
+    batchv1 "k8s.io/api/batch/v1"
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/api/resource"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+    "k8s.io/apimachinery/pkg/labels"
+    "k8s.io/apimachinery/pkg/runtime"
+    "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+    packtv1 "github.com/PacktPublishing/Kubernetes-Interview-Guide/chapter-12/books-operator/api/v1"
+)
+
+// WorkloadRenderer reconciles book's chosen workload kind to the desired
+// replica count and reports back how many instances are ready, so the caller
+// can fold that into BookStatus.Conditions the same way for every kind.
+type WorkloadRenderer interface {
+    Reconcile(ctx context.Context, c client.Client, scheme *runtime.Scheme, book *packtv1.Book, desired int32) (ready int32, err error)
+}
+
+// rendererFor returns the WorkloadRenderer for book's WorkloadKind, defaulting
+// to Pod when unset.
+func rendererFor(kind packtv1.WorkloadKind) WorkloadRenderer {
+    switch effectiveWorkloadKind(kind) {
+    case packtv1.WorkloadKindDeployment:
+        return deploymentRenderer{}
+    case packtv1.WorkloadKindJob:
+        return jobRenderer{}
+    case packtv1.WorkloadKindCronJob:
+        return cronJobRenderer{}
+    default:
+        return podRenderer{}
+    }
+}
+
+// effectiveWorkloadKind returns kind, defaulting to Pod when unset, so
+// callers can compare it against a previously observed kind without
+// special-casing the empty string.
+func effectiveWorkloadKind(kind packtv1.WorkloadKind) packtv1.WorkloadKind {
+    if kind == "" {
+        return packtv1.WorkloadKindPod
+    }
+    return kind
+}
+
+// cleanupStaleWorkload deletes the resources rendered for book's previous
+// WorkloadKind after Spec.WorkloadKind has changed, so switching kinds
+// doesn't leave the old kind's workload running alongside the new one.
+func cleanupStaleWorkload(ctx context.Context, c client.Client, book *packtv1.Book, staleKind packtv1.WorkloadKind) error {
+    switch staleKind {
+    case packtv1.WorkloadKindPod:
+        podList := &corev1.PodList{}
+        selector := labels.SelectorFromSet(labels.Set{bookNameLabel: book.Name})
+        if err := c.List(ctx, podList, client.InNamespace(book.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+            return fmt.Errorf("listing stale Pods: %w", err)
+        }
+        for i := range podList.Items {
+            if err := c.Delete(ctx, &podList.Items[i]); err != nil && client.IgnoreNotFound(err) != nil {
+                return fmt.Errorf("deleting stale Pod: %w", err)
+            }
+        }
+        book.Status.PodNames = nil
+    case packtv1.WorkloadKindDeployment:
+        dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: book.Name, Namespace: book.Namespace}}
+        if err := c.Delete(ctx, dep); err != nil && client.IgnoreNotFound(err) != nil {
+            return fmt.Errorf("deleting stale Deployment: %w", err)
+        }
+    case packtv1.WorkloadKindJob:
+        job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: book.Name, Namespace: book.Namespace}}
+        if err := c.Delete(ctx, job); err != nil && client.IgnoreNotFound(err) != nil {
+            return fmt.Errorf("deleting stale Job: %w", err)
+        }
+    case packtv1.WorkloadKindCronJob:
+        cj := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: book.Name, Namespace: book.Namespace}}
+        if err := c.Delete(ctx, cj); err != nil && client.IgnoreNotFound(err) != nil {
+            return fmt.Errorf("deleting stale CronJob: %w", err)
+        }
+    }
+    return nil
+}
+
+// bookPodTemplate returns book's PodTemplate override if set, otherwise the
+// default single-container busybox template used throughout the chapter,
+// with book's DeletionPolicy baked into the grace period and PreStop hook.
+// bookNameLabel is forced onto the result either way, since every renderer
+// relies on it to find the Pods it owns and a caller-supplied PodTemplate has
+// no reason to know about this internal bookkeeping label.
+func bookPodTemplate(book *packtv1.Book) corev1.PodTemplateSpec {
+    var template corev1.PodTemplateSpec
+    if book.Spec.PodTemplate != nil {
+        template = *book.Spec.PodTemplate
+    } else {
+        template = defaultBookPodTemplate(book)
+    }
+    if template.Labels == nil {
+        template.Labels = map[string]string{}
+    }
+    template.Labels[bookNameLabel] = book.Name
+    applyDeletionPolicy(&template, book.Spec.DeletionPolicy)
+    return template
+}
+
+func defaultBookPodTemplate(book *packtv1.Book) corev1.PodTemplateSpec {
+    return corev1.PodTemplateSpec{
+        ObjectMeta: metav1.ObjectMeta{
+            Labels: map[string]string{bookNameLabel: book.Name},
+        },
+        Spec: corev1.PodSpec{
+            Containers: []corev1.Container{
+                {
+                    Name:  "busybox",
+                    Image: "busybox:1.36", // Pin image version for reproducibility
+                    Command: []string{
+                        "sh",
+                        "-c",
+                        fmt.Sprintf("while true; do echo Book: %s, Year: %d; sleep 1; done", book.Spec.Book, book.Spec.Year),
+                    },
+                    Resources: corev1.ResourceRequirements{
+                        Requests: corev1.ResourceList{
+                            corev1.ResourceCPU:    resource.MustParse("100m"),
+                            corev1.ResourceMemory: resource.MustParse("128Mi"),
+                        },
+                        Limits: corev1.ResourceList{
+                            corev1.ResourceCPU:    resource.MustParse("200m"),
+                            corev1.ResourceMemory: resource.MustParse("256Mi"),
+                        },
+                    },
+                },
+            },
+        },
+    }
+}
+
+// podRenderer owns one Pod per replica directly, since a bare Pod has no
+// built-in notion of replica count or rolling update.
+type podRenderer struct{}
+
+func (podRenderer) Reconcile(ctx context.Context, c client.Client, scheme *runtime.Scheme, book *packtv1.Book, desired int32) (int32, error) {
+    podList := &corev1.PodList{}
+    selector := labels.SelectorFromSet(labels.Set{bookNameLabel: book.Name})
+    book.Status.Selector = selector.String()
+    if err := c.List(ctx, podList, client.InNamespace(book.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+        return 0, fmt.Errorf("listing owned Pods: %w", err)
+    }
+
+    owned := podList.Items
+    current := int32(len(owned))
+    template := bookPodTemplate(book)
+
+    if current < desired {
+        for i := int32(0); i < desired-current; i++ {
+            pod := &corev1.Pod{
+                ObjectMeta: metav1.ObjectMeta{
+                    GenerateName: book.Name + "-pod-",
+                    Namespace:    book.Namespace,
+                    Labels:       template.Labels,
+                    OwnerReferences: []metav1.OwnerReference{
+                        *metav1.NewControllerRef(book, packtv1.GroupVersion.WithKind("Book")),
+                    },
+                },
+                Spec: template.Spec,
+            }
+            if err := c.Create(ctx, pod); err != nil {
+                return 0, fmt.Errorf("creating Pod: %w", err)
+            }
+            owned = append(owned, *pod)
+        }
+    }
+
+    if current > desired {
+        surplus := owned[desired:]
+        owned = owned[:desired]
+        for i := range surplus {
+            if err := c.Delete(ctx, &surplus[i]); err != nil && client.IgnoreNotFound(err) != nil {
+                return 0, fmt.Errorf("deleting surplus Pod: %w", err)
+            }
+        }
+    }
+
+    podNames := make([]string, 0, len(owned))
+    var ready int32
+    for i := range owned {
+        podNames = append(podNames, owned[i].Name)
+        if owned[i].Status.Phase == corev1.PodRunning {
+            ready++
+        }
+    }
+    book.Status.PodNames = podNames
+
+    return ready, nil
+}
+
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+
+// deploymentRenderer reconciles a single Deployment, letting its own rolling
+// update logic apply PodTemplate changes.
+type deploymentRenderer struct{}
+
+func (deploymentRenderer) Reconcile(ctx context.Context, c client.Client, scheme *runtime.Scheme, book *packtv1.Book, desired int32) (int32, error) {
+    dep := &appsv1.Deployment{
+        ObjectMeta: metav1.ObjectMeta{Name: book.Name, Namespace: book.Namespace},
+    }
+
+    book.Status.Selector = labels.SelectorFromSet(labels.Set{bookNameLabel: book.Name}).String()
+
+    _, err := controllerutil.CreateOrUpdate(ctx, c, dep, func() error {
+        dep.Spec.Replicas = &desired
+        dep.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{bookNameLabel: book.Name}}
+        dep.Spec.Template = bookPodTemplate(book)
+        return controllerutil.SetControllerReference(book, dep, scheme)
+    })
+    if err != nil {
+        return 0, fmt.Errorf("reconciling Deployment: %w", err)
+    }
+
+    return dep.Status.ReadyReplicas, nil
+}
+
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+
+// jobRenderer reconciles a single Job, mapping desired replicas onto
+// Parallelism and Completions.
+type jobRenderer struct{}
+
+func (jobRenderer) Reconcile(ctx context.Context, c client.Client, scheme *runtime.Scheme, book *packtv1.Book, desired int32) (int32, error) {
+    job := &batchv1.Job{
+        ObjectMeta: metav1.ObjectMeta{Name: book.Name, Namespace: book.Namespace},
+    }
+
+    book.Status.Selector = labels.SelectorFromSet(labels.Set{bookNameLabel: book.Name}).String()
+
+    err := c.Get(ctx, client.ObjectKeyFromObject(job), job)
+    switch {
+    case err == nil:
+        // Job specs are immutable once created; nothing to update in place.
+    case client.IgnoreNotFound(err) != nil:
+        return 0, fmt.Errorf("getting Job: %w", err)
+    default:
+        template := bookPodTemplate(book)
+        template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+        job.Spec = batchv1.JobSpec{
+            Parallelism: &desired,
+            Completions: &desired,
+            Template:    template,
+        }
+        if err := controllerutil.SetControllerReference(book, job, scheme); err != nil {
+            return 0, fmt.Errorf("setting owner reference: %w", err)
+        }
+        if err := c.Create(ctx, job); err != nil {
+            return 0, fmt.Errorf("creating Job: %w", err)
+        }
+    }
+
+    return job.Status.Succeeded + job.Status.Active, nil
+}
+
+//+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
+
+// cronJobRenderer reconciles a single CronJob on book.Spec.Schedule, mapping
+// desired replicas onto the inner Job template's Parallelism/Completions.
+type cronJobRenderer struct{}
+
+func (cronJobRenderer) Reconcile(ctx context.Context, c client.Client, scheme *runtime.Scheme, book *packtv1.Book, desired int32) (int32, error) {
+    cj := &batchv1.CronJob{
+        ObjectMeta: metav1.ObjectMeta{Name: book.Name, Namespace: book.Namespace},
+    }
+
+    book.Status.Selector = labels.SelectorFromSet(labels.Set{bookNameLabel: book.Name}).String()
+
+    _, err := controllerutil.CreateOrUpdate(ctx, c, cj, func() error {
+        template := bookPodTemplate(book)
+        template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+
+        cj.Spec.Schedule = book.Spec.Schedule
+        cj.Spec.JobTemplate.Spec = batchv1.JobSpec{
+            Parallelism: &desired,
+            Completions: &desired,
+            Template:    template,
+        }
+        return controllerutil.SetControllerReference(book, cj, scheme)
+    })
+    if err != nil {
+        return 0, fmt.Errorf("reconciling CronJob: %w", err)
+    }
+
+    // Readiness is the latest spawned Job's Succeeded plus Active count, the
+    // same way jobRenderer does, so long-running Pod templates (like the
+    // default busybox loop) whose inner Job never reaches Succeeded still
+    // count as ready while running.
+    jobList := &batchv1.JobList{}
+    if err := c.List(ctx, jobList, client.InNamespace(book.Namespace)); err != nil {
+        return 0, fmt.Errorf("listing CronJob's Jobs: %w", err)
+    }
+
+    var latest *batchv1.Job
+    for i := range jobList.Items {
+        job := &jobList.Items[i]
+        if !metav1.IsControlledBy(job, cj) {
+            continue
+        }
+        if latest == nil || job.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+            latest = job
+        }
+    }
+    if latest == nil {
+        return 0, nil
+    }
+    return latest.Status.Succeeded + latest.Status.Active, nil
+}